@@ -10,7 +10,8 @@
 //   go build -o gowatch && ./gowatch -- go run ./cmd/myapp
 //
 // Notes:
-// - Uses polling (interval default 500ms) so it works cross-platform without fsnotify.
+// - Defaults to -backend=auto: OS-level fsnotify events when available,
+//   falling back to polling (interval default 500ms) otherwise.
 // - Watches .go, .mod, .sum, .tpl, .html, .css, .js files by default. Change `extensions` if desired.
 
 package main
@@ -25,6 +26,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ayushdoesdev/goblip/internal/builder"
+	"github.com/ayushdoesdev/goblip/internal/ctrl"
+	"github.com/ayushdoesdev/goblip/internal/reaper"
 	"github.com/ayushdoesdev/goblip/internal/runner"
 	"github.com/ayushdoesdev/goblip/internal/watcher"
 )
@@ -34,14 +38,29 @@ var (
 	extsFlag     = flag.String("ext", ".go,.mod,.sum,.tpl,.html,.css,.js", "comma-separated list of file extensions to watch")
 	ignoreVcs    = flag.Bool("ignore-vcs", true, "ignore .git, .hg, .svn directories")
 	verbose      = flag.Bool("v", false, "verbose output")
+	buildFlag    = flag.Bool("build", false, "build before restarting instead of re-running the raw command (go build -o <tmp>)")
+	debounceFlag = flag.Duration("debounce", 300*time.Millisecond, "coalesce file events within this window before rebuilding/restarting")
+	backendFlag  = flag.String("backend", "auto", "file watching backend: poll, event, or auto (event with polling fallback)")
+	listenFlag   = flag.String("listen", "", "expose a WebSocket/NDJSON control plane on this address (e.g. :9000); disabled if empty")
+	reapFlag     = flag.Bool("reap", false, "install as a Linux subreaper and reap orphaned grandchildren (also enabled by GOBLIP_REAP=1); for container entrypoint use")
+	portFlag     = flag.Int("port", 0, "port to wait for release on restart; 0 auto-detects from the command line or PORT/HTTP_PORT/ADDR env vars")
 )
 
 func main() {
 	flag.Parse()
 
 	cmdArgs := flag.Args()
+
+	var b *builder.Builder
 	var runCmd string
-	if len(cmdArgs) == 0 {
+	if *buildFlag {
+		b = builder.New("", *verbose)
+		binPath, err := doBuild(b)
+		if err != nil {
+			os.Exit(1)
+		}
+		runCmd = strings.Join(append([]string{binPath}, cmdArgs...), " ")
+	} else if len(cmdArgs) == 0 {
 		runCmd = "go run ."
 	} else {
 		runCmd = strings.Join(cmdArgs, " ")
@@ -53,19 +72,48 @@ func main() {
 		fmt.Printf("Watching extensions: %v\n", exts)
 		fmt.Printf("Command to run: %s\n", runCmd)
 		fmt.Printf("Poll interval: %v\n", *intervalFlag)
+		fmt.Printf("Debounce: %v\n", *debounceFlag)
+		if *buildFlag {
+			fmt.Println("Build mode: on")
+		}
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	w := watcher.New(*intervalFlag, exts, *ignoreVcs, *verbose)
+	w, err := watcher.New(watcher.Options{
+		Backend:   watcher.Backend(*backendFlag),
+		Interval:  *intervalFlag,
+		Exts:      exts,
+		IgnoreVcs: *ignoreVcs,
+		Verbose:   *verbose,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "watcher init error: %v\n", err)
+		os.Exit(1)
+	}
 	restartCh, err := w.Start(ctx)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "watcher start error: %v\n", err)
 		os.Exit(1)
 	}
+	restartEvents := debounce(restartCh, *debounceFlag)
+
+	r := runner.New(runCmd, *verbose, reaper.ShouldEnable(*reapFlag))
+	r.Port = *portFlag
+
+	// ctrlSrv is wired up before the first Start so it can tee the initial
+	// run's stdout/stderr to subscribers too, not just output from restarts.
+	var ctrlSrv *ctrl.Server
+	if *listenFlag != "" {
+		ctrlSrv = ctrl.New(r, *verbose)
+		go func() {
+			if err := ctrlSrv.ListenAndServe(*listenFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "control plane error: %v\n", err)
+			}
+		}()
+	}
 
-	r := runner.New(runCmd, *verbose)
 	if err := r.Start(); err != nil {
 		fmt.Fprintf(os.Stderr, "failed to start command: %v\n", err)
 		os.Exit(1)
@@ -76,15 +124,36 @@ func main() {
 
 	for {
 		select {
-		case <-restartCh:
+		case <-restartEvents:
 			if *verbose {
-				fmt.Println("[gowatch] restarting child")
+				fmt.Println("[gowatch] change detected")
+			}
+			if *buildFlag {
+				binPath, err := doBuild(b)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "[gowatch] keeping last-good binary running")
+					if ctrlSrv != nil {
+						ctrlSrv.Broadcast(ctrl.Frame{Type: "build-failed", Data: err.Error()})
+					}
+					continue
+				}
+				newCmd := strings.Join(append([]string{binPath}, cmdArgs...), " ")
+				if err := r.Restart(newCmd); err != nil {
+					fmt.Fprintf(os.Stderr, "failed to restart command: %v\n", err)
+				}
+				if ctrlSrv != nil {
+					ctrlSrv.Broadcast(ctrl.Frame{Type: "restart"})
+				}
+				continue
 			}
 			r.Stop()
 			time.Sleep(100 * time.Millisecond)
 			if err := r.Start(); err != nil {
 				fmt.Fprintf(os.Stderr, "failed to restart command: %v\n", err)
 			}
+			if ctrlSrv != nil {
+				ctrlSrv.Broadcast(ctrl.Frame{Type: "restart"})
+			}
 		case s := <-sigs:
 			if *verbose {
 				fmt.Printf("[gowatch] received signal: %v\n", s)
@@ -94,3 +163,50 @@ func main() {
 		}
 	}
 }
+
+// doBuild runs b.Build, printing a formatted error banner on failure so the
+// user sees the compile error instead of a silent restart skip.
+func doBuild(b *builder.Builder) (string, error) {
+	binPath, err := b.Build()
+	if err != nil {
+		if be, ok := err.(*builder.BuildError); ok {
+			fmt.Fprint(os.Stderr, builder.Banner(be))
+		} else {
+			fmt.Fprintf(os.Stderr, "build error: %v\n", err)
+		}
+		return "", err
+	}
+	return binPath, nil
+}
+
+// debounce coalesces bursts of restart signals (common when editors write
+// many files in quick succession) into a single event per quiet window d.
+//
+// out is never closed: timer.Stop() doesn't guarantee the AfterFunc
+// callback isn't already running, so a timer firing concurrently with the
+// loop exit (ctx cancellation) could otherwise send on a closed channel
+// and panic. The goroutine below simply exits when in is drained, and its
+// timer goroutine (if any) is stopped; both die harmlessly with the
+// process.
+func debounce(in <-chan struct{}, d time.Duration) <-chan struct{} {
+	out := make(chan struct{}, 1)
+	go func() {
+		var timer *time.Timer
+		for range in {
+			if timer == nil {
+				timer = time.AfterFunc(d, func() {
+					select {
+					case out <- struct{}{}:
+					default:
+					}
+				})
+				continue
+			}
+			timer.Reset(d)
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	return out
+}
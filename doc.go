@@ -1,10 +1,12 @@
-// Package goblip provides a lightweight, dependency-free file-watching
-// utility for Go development. It automatically detects source changes
-// and restarts a running process, similar to nodemon or air.
+// Package goblip provides a lightweight file-watching utility for Go
+// development. It automatically detects source changes and restarts a
+// running process, similar to nodemon or air.
 //
 // GoBlip is designed for fast, simple local development of Go applications,
-// web servers, or CLI tools. It works entirely with the Go standard library
-// and uses polling (not fsnotify) for maximum cross-platform compatibility.
+// web servers, or CLI tools. By default it uses OS-level filesystem events
+// (via fsnotify) for low-latency change detection, falling back to polling
+// when that isn't available on the target platform or filesystem. Pass
+// -backend=poll to force polling for maximum cross-platform compatibility.
 //
 // Typical usage:
 //
@@ -24,6 +26,38 @@
 //
 //   goblip -ext ".go,.tpl" -interval 300ms -- go run ./cmd/server
 //
+// With -build, GoBlip compiles the project before restarting instead of
+// re-running the raw command, so type-check errors are caught and
+// surfaced instead of hiding behind a `go run` failure. Rapid bursts of
+// file events (common during editor saves) are coalesced with -debounce.
+// With -build, the arguments after -- are passed to the built binary:
+//
+//   goblip -build -debounce 300ms -- -addr :8080
+//
+// With -listen, GoBlip also exposes a WebSocket (/ws) and NDJSON
+// (/events) control plane so an editor plugin or remote container can
+// subscribe to output and send restart/stop/signal/setcmd commands:
+//
+//   goblip -listen :9000 -- go run .
+//
+// -listen has no authentication, so only bind it to localhost or a
+// trusted private network — anyone who can reach it can restart or
+// reconfigure the child process.
+//
+// With -reap (or GOBLIP_REAP=1), GoBlip installs itself as a Linux
+// subreaper and reaps orphaned grandchildren, which is needed when
+// GoBlip is PID 1 of a hot-reload dev container:
+//
+//   goblip -reap -- go run .
+//
+// Before restarting, GoBlip waits for the previous child's port to be
+// released by checking the kernel's own listening-socket state (not a
+// TCP dial, which can't tell "free" from "nothing listening yet"). The
+// port is auto-detected from the command line or PORT/HTTP_PORT/ADDR
+// env vars, or pinned explicitly with -port:
+//
+//   goblip -port 3000 -- go run . -addr=:3000
+//
 // GoBlip is framework-agnostic â€” it works equally well with Gin, Fiber, Echo,
 // Chi, or any other Go application that you want to auto-restart on file changes.
 //
@@ -0,0 +1,98 @@
+// Package builder implements the build phase of GoBlip's two-phase
+// build-then-run workflow: it compiles a project into a temporary binary
+// before the runner restarts the child process, so a broken build never
+// kills a working process with an untyped `go run` failure.
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// DefaultCmd is the build command used when none is supplied. The %s verb
+// is replaced with a temp binary path chosen by the Builder.
+const DefaultCmd = "go build -o %s ."
+
+// Builder compiles a project using a configurable shell command, reusing
+// the same output binary path across builds so callers can keep the
+// last-good binary around if a build fails.
+type Builder struct {
+	Cmd     string
+	Verbose bool
+
+	binPath string
+}
+
+// New creates a Builder. If cmdStr is empty, DefaultCmd is used.
+func New(cmdStr string, verbose bool) *Builder {
+	if cmdStr == "" {
+		cmdStr = DefaultCmd
+	}
+	return &Builder{Cmd: cmdStr, Verbose: verbose}
+}
+
+// Build compiles the project into a temp binary and returns its path.
+// On failure, a *BuildError wrapping the captured stderr is returned and
+// the previous binary on disk (if any) is left untouched, so the caller
+// can keep running the last-good build.
+func (b *Builder) Build() (string, error) {
+	if b.binPath == "" {
+		tmp, err := os.CreateTemp("", "goblip-build-*")
+		if err != nil {
+			return "", fmt.Errorf("builder: allocate temp binary: %w", err)
+		}
+		tmp.Close()
+		os.Remove(tmp.Name())
+		b.binPath = tmp.Name()
+	}
+
+	cmdStr := fmt.Sprintf(b.Cmd, b.binPath)
+	if b.Verbose {
+		fmt.Printf("[gowatch] building: %s\n", cmdStr)
+	}
+
+	// Run through a shell, the same way Runner.Start does, rather than
+	// splitting on whitespace: os.TempDir() commonly contains spaces
+	// (e.g. "C:\Users\John Doe\AppData\Local\Temp" on Windows), which
+	// would otherwise split the substituted binary path into bogus args.
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", cmdStr)
+	} else {
+		cmd = exec.Command("sh", "-c", cmdStr)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", &BuildError{Output: stderr.String(), Err: err}
+	}
+
+	return b.binPath, nil
+}
+
+// BuildError reports a failed build along with the captured stderr output.
+type BuildError struct {
+	Output string
+	Err    error
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("build failed: %v\n%s", e.Err, e.Output)
+}
+
+func (e *BuildError) Unwrap() error { return e.Err }
+
+// Banner formats a BuildError as a human-readable error banner suitable
+// for printing to stderr.
+func Banner(err *BuildError) string {
+	var b strings.Builder
+	b.WriteString("==================== BUILD FAILED ====================\n")
+	b.WriteString(strings.TrimRight(err.Output, "\n"))
+	b.WriteString("\n=======================================================\n")
+	return b.String()
+}
@@ -0,0 +1,215 @@
+// Package ctrl implements an optional WebSocket/HTTP control plane that
+// exposes a runner.Runner to remote clients — editor plugins, web UIs, or
+// sibling containers — so they can subscribe to child output and issue
+// restart/stop/signal commands without shelling into the dev loop.
+//
+// There is no authentication: anyone who can reach -listen's address can
+// restart or reconfigure the child process. Only bind it to localhost or
+// a trusted private network, never a public interface.
+package ctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ayushdoesdev/goblip/internal/runner"
+)
+
+// Frame is a single JSON event sent from server to client, over either
+// the WebSocket connection or the /events NDJSON stream.
+type Frame struct {
+	Type string `json:"type"` // "stdout" | "stderr" | "restart" | "build-failed"
+	Data string `json:"data,omitempty"`
+}
+
+// Server exposes a runner.Runner over WebSocket (/ws) and NDJSON
+// (/events) for remote control of the dev loop. The CLI works standalone
+// when no Server is started.
+type Server struct {
+	Runner  *runner.Runner
+	Verbose bool
+
+	mu       sync.Mutex
+	clients  map[*client]struct{}
+	upgrader websocket.Upgrader
+}
+
+// New creates a Server wrapping r, wiring r.Stdout/r.Stderr so every byte
+// the child writes is also broadcast to connected clients as "stdout"/
+// "stderr" frames.
+func New(r *runner.Runner, verbose bool) *Server {
+	s := &Server{
+		Runner:  r,
+		Verbose: verbose,
+		clients: make(map[*client]struct{}),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin,
+		},
+	}
+	r.Stdout = broadcastWriter{s: s, kind: "stdout"}
+	r.Stderr = broadcastWriter{s: s, kind: "stderr"}
+	return s
+}
+
+// broadcastWriter is an io.Writer adapter that broadcasts every Write as
+// a Frame, letting Runner tee child output into the control plane the
+// same way it writes to the parent's own stdout/stderr.
+type broadcastWriter struct {
+	s    *Server
+	kind string
+}
+
+func (w broadcastWriter) Write(p []byte) (int, error) {
+	w.s.Broadcast(Frame{Type: w.kind, Data: string(p)})
+	return len(p), nil
+}
+
+// Broadcast fans a frame out to every connected client (WebSocket and
+// /events): child stdout/stderr as it's written, and restart/build-failed
+// events as they happen.
+func (s *Server) Broadcast(f Frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		select {
+		case c.out <- f:
+		default:
+		}
+	}
+}
+
+// ListenAndServe starts the control plane on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.handleWS)
+	mux.HandleFunc("/events", s.handleEvents)
+	if s.Verbose {
+		fmt.Printf("[gowatch] control plane listening on %s\n", addr)
+	}
+	return http.ListenAndServe(addr, mux)
+}
+
+// checkOrigin rejects cross-site WebSocket upgrades: without this, any
+// webpage open in the developer's browser could connect to ws://<listen>/ws
+// and drive setcmd:/restart control messages (cross-site WebSocket
+// hijacking into shell execution). Requests with no Origin header (editor
+// plugins, curl, other non-browser clients) are allowed through.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	default:
+		return false
+	}
+}
+
+// client is one subscriber, reached either via WebSocket or the /events
+// long-lived NDJSON response.
+type client struct {
+	conn *websocket.Conn
+	out  chan Frame
+}
+
+func (s *Server) addClient(c *client) {
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *Server) removeClient(c *client) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+	close(c.out)
+}
+
+// handleWS upgrades the connection and runs two goroutines: one
+// multiplexing child output/events out to the client, one pumping
+// incoming control messages in.
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		if s.Verbose {
+			fmt.Printf("[gowatch] ws upgrade error: %v\n", err)
+		}
+		return
+	}
+	defer conn.Close()
+
+	c := &client{conn: conn, out: make(chan Frame, 32)}
+	s.addClient(c)
+	defer s.removeClient(c)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for f := range c.out {
+			if err := conn.WriteJSON(f); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.handleControl(strings.TrimSpace(string(msg)))
+	}
+}
+
+// handleEvents serves child output/events as newline-delimited JSON for
+// clients that can't speak WebSocket.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	c := &client{out: make(chan Frame, 32)}
+	s.addClient(c)
+	defer s.removeClient(c)
+
+	enc := json.NewEncoder(w)
+	for f := range c.out {
+		if err := enc.Encode(f); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// handleControl dispatches a single control message: "restart", "stop",
+// "signal:SIGUSR1", or "setcmd:<newcmd>".
+func (s *Server) handleControl(msg string) {
+	switch {
+	case msg == "restart":
+		s.Runner.Stop()
+		_ = s.Runner.Start()
+	case msg == "stop":
+		s.Runner.Stop()
+	case strings.HasPrefix(msg, "signal:"):
+		if sig, ok := signalByName[strings.TrimPrefix(msg, "signal:")]; ok {
+			s.Runner.Signal(sig)
+		}
+	case strings.HasPrefix(msg, "setcmd:"):
+		_ = s.Runner.Restart(strings.TrimPrefix(msg, "setcmd:"))
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package ctrl
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalByName maps the signal names accepted in "signal:<name>" control
+// messages to os.Signal values on Unix.
+var signalByName = map[string]os.Signal{
+	"SIGINT":  syscall.SIGINT,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGKILL": syscall.SIGKILL,
+}
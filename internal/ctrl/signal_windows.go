@@ -0,0 +1,15 @@
+//go:build windows
+
+package ctrl
+
+import "os"
+
+// signalByName maps the signal names accepted in "signal:<name>" control
+// messages to os.Signal values on Windows, which has no POSIX signal set.
+// Only interrupt and kill are meaningful here; Runner.Stop itself uses
+// taskkill /T to terminate the process tree.
+var signalByName = map[string]os.Signal{
+	"SIGINT":  os.Interrupt,
+	"SIGTERM": os.Kill,
+	"SIGKILL": os.Kill,
+}
@@ -0,0 +1,82 @@
+// Package portcheck determines whether a TCP port a restarted child is
+// about to bind is actually free. net.DialTimeout returning an error does
+// NOT mean a port is free — it may just mean nothing is listening there
+// yet — so instead this package inspects the kernel's own listening-socket
+// state, via per-OS files (portcheck_linux.go, portcheck_windows.go,
+// portcheck_darwin.go).
+package portcheck
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// portPatterns recognizes common ways a command line names a port, well
+// beyond a bare ":8080" — e.g. "-addr=:3000", "-port 3000".
+var portPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-{0,2}addr[= ]:?(\d{2,5})\b`),
+	regexp.MustCompile(`-{0,2}port[= ]:?(\d{2,5})\b`),
+	regexp.MustCompile(`:(\d{2,5})\b`),
+}
+
+// envVarNames are the environment variable names sniffed, in order, when
+// no port can be found in the command line itself.
+var envVarNames = []string{"PORT", "HTTP_PORT", "ADDR", "SERVER_ADDR"}
+
+// ExtractPort finds the port a command is likely to bind: first by
+// pattern-matching the command string, then by sniffing env (typically
+// os.Environ()) for common port variables. ok is false if nothing is
+// found, so the caller can fall back to a default.
+func ExtractPort(cmdStr string, env []string) (port int, ok bool) {
+	for _, re := range portPatterns {
+		m := re.FindStringSubmatch(cmdStr)
+		if m == nil {
+			continue
+		}
+		if p, err := strconv.Atoi(m[1]); err == nil {
+			return p, true
+		}
+	}
+	for _, name := range envVarNames {
+		prefix := name + "="
+		for _, kv := range env {
+			if !strings.HasPrefix(kv, prefix) {
+				continue
+			}
+			val := strings.TrimPrefix(strings.TrimPrefix(kv, prefix), ":")
+			if p, err := strconv.Atoi(val); err == nil {
+				return p, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// WaitForRelease polls IsListening until port is no longer bound or
+// timeout elapses. It returns true once the port is confirmed free, and
+// also returns true at once if IsListening fails; it returns false only
+// when timeout elapses with the port still bound.
+func WaitForRelease(port int, timeout time.Duration, verbose bool) bool {
+	start := time.Now()
+	for time.Since(start) < timeout {
+		listening, err := IsListening(port)
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "[gowatch] portcheck: %v\n", err)
+			}
+			return true // can't tell; don't block the restart on it
+		}
+		if !listening {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if verbose {
+		fmt.Printf("[gowatch] warning: port %d still appears to be in use\n", port)
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+//go:build darwin
+
+package portcheck
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// IsListening reports whether any socket in the LISTEN state is bound to
+// port, via netstat since macOS has no /proc/net/tcp-style table.
+func IsListening(port int) (bool, error) {
+	out, err := exec.Command("netstat", "-an", "-p", "tcp").Output()
+	if err != nil {
+		return false, fmt.Errorf("portcheck: netstat: %w", err)
+	}
+
+	want := strconv.Itoa(port)
+	for _, line := range strings.Split(string(out), "\n") {
+		if !strings.Contains(line, "LISTEN") {
+			continue
+		}
+		fields := strings.Fields(line)
+		// Proto Recv-Q Send-Q Local-Address Foreign-Address (state)
+		if len(fields) < 4 {
+			continue
+		}
+		// macOS prints addr.port; match the exact suffix after the last dot.
+		localAddr := fields[3]
+		if idx := strings.LastIndex(localAddr, "."); idx != -1 && localAddr[idx+1:] == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
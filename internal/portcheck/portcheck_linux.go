@@ -0,0 +1,58 @@
+//go:build linux
+
+package portcheck
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tcpListenState is the hex state value /proc/net/tcp{,6} use for LISTEN.
+const tcpListenState = "0A"
+
+// IsListening reports whether any socket in the LISTEN state is bound to
+// port, by reading /proc/net/tcp and /proc/net/tcp6 directly instead of
+// attempting a TCP dial.
+func IsListening(port int) (bool, error) {
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		listening, err := scanProcNetTCP(path, port)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if listening {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func scanProcNetTCP(path string, port int) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	want := fmt.Sprintf("%04X", port)
+	sc := bufio.NewScanner(f)
+	sc.Scan() // header line
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		addrParts := strings.Split(fields[1], ":")
+		if len(addrParts) != 2 {
+			continue
+		}
+		if strings.EqualFold(addrParts[1], want) && fields[3] == tcpListenState {
+			return true, nil
+		}
+	}
+	return false, sc.Err()
+}
@@ -0,0 +1,37 @@
+//go:build windows
+
+package portcheck
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// IsListening reports whether any socket in the LISTENING state is bound
+// to port, via netstat since Windows has no /proc/net/tcp-style table.
+func IsListening(port int) (bool, error) {
+	out, err := exec.Command("netstat", "-ano", "-p", "TCP").Output()
+	if err != nil {
+		return false, fmt.Errorf("portcheck: netstat: %w", err)
+	}
+
+	want := strconv.Itoa(port)
+	for _, line := range strings.Split(string(out), "\n") {
+		if !strings.Contains(line, "LISTENING") {
+			continue
+		}
+		fields := strings.Fields(line)
+		// Proto Local-Address Foreign-Address State PID
+		if len(fields) < 2 {
+			continue
+		}
+		// Local address is host:port; match the exact suffix after the last colon.
+		localAddr := fields[1]
+		if idx := strings.LastIndex(localAddr, ":"); idx != -1 && localAddr[idx+1:] == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,46 @@
+// Package reaper implements optional PID-1-safe child reaping for
+// container usage. When GoBlip is a container's entrypoint, orphan
+// grandchildren spawned by `sh -c "go run ."` accumulate as zombies
+// because nothing ever waits on them. Enabling subreaper mode makes
+// GoBlip adopt those orphans (via PR_SET_CHILD_SUBREAPER on Linux) and
+// reap them itself.
+package reaper
+
+import "os"
+
+// ExitEvent reports that some descendant process exited.
+type ExitEvent struct {
+	Pid      int
+	ExitCode int
+}
+
+// Reaper installs itself as a subreaper and reaps exited descendants,
+// forwarding each one's exit status on Exits so callers can tell their
+// own child dying apart from an unrelated grandchild.
+type Reaper struct {
+	// Exits receives an ExitEvent for every descendant reaped once Enable
+	// has succeeded. Unbuffered readers should drain it promptly; sends
+	// are dropped if the buffer is full rather than blocking the handler.
+	Exits chan ExitEvent
+
+	enabled bool
+}
+
+// Default is the process-wide Reaper singleton. Both Runner.Start's wait
+// goroutine and the SIGCHLD handler installed by Enable use it, so the
+// two wait paths never race over the same child.
+var Default = New()
+
+// New creates a Reaper. Most callers should use Default instead.
+func New() *Reaper {
+	return &Reaper{Exits: make(chan ExitEvent, 16)}
+}
+
+// Enabled reports whether Enable has succeeded on this platform.
+func (r *Reaper) Enabled() bool { return r.enabled }
+
+// ShouldEnable reports whether subreaper mode was requested, either via a
+// command-line flag or the GOBLIP_REAP=1 environment variable.
+func ShouldEnable(flagSet bool) bool {
+	return flagSet || os.Getenv("GOBLIP_REAP") == "1"
+}
@@ -0,0 +1,47 @@
+//go:build linux
+
+package reaper
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Enable marks the current process as a Linux child subreaper and starts
+// a SIGCHLD handler that reaps all exited descendants via
+// waitpid(-1, WNOHANG), forwarding each one's exit status on r.Exits.
+func (r *Reaper) Enable() error {
+	if err := unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("reaper: set subreaper: %w", err)
+	}
+	r.enabled = true
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGCHLD)
+	go func() {
+		for range sigs {
+			r.reapAll()
+		}
+	}()
+	return nil
+}
+
+// reapAll drains every exited descendant without blocking, so a burst of
+// SIGCHLDs (e.g. many grandchildren dying at once) is handled in one pass.
+func (r *Reaper) reapAll() {
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, nil)
+		if pid <= 0 || err != nil {
+			return
+		}
+		select {
+		case r.Exits <- ExitEvent{Pid: pid, ExitCode: ws.ExitStatus()}:
+		default:
+		}
+	}
+}
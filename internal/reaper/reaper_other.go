@@ -0,0 +1,12 @@
+//go:build !linux
+
+package reaper
+
+import "fmt"
+
+// Enable is a no-op outside Linux: subreaper semantics
+// (PR_SET_CHILD_SUBREAPER) are Linux-specific, and other platforms don't
+// accumulate PID-1 zombies from a container entrypoint the same way.
+func (r *Reaper) Enable() error {
+	return fmt.Errorf("reaper: subreaper mode is only supported on linux")
+}
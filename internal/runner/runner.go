@@ -2,15 +2,17 @@ package runner
 
 import (
 	"fmt"
-	"net"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/ayushdoesdev/goblip/internal/portcheck"
+	"github.com/ayushdoesdev/goblip/internal/reaper"
 )
 
 // Runner manages a child process started from a shell command string.
@@ -18,49 +20,40 @@ type Runner struct {
 	CmdStr  string
 	Verbose bool
 
-	mu  sync.Mutex
-	cmd *exec.Cmd
-}
-
-// extractPort attempts to extract the port number from the command string
-func (r *Runner) extractPort() int {
-	// Common port patterns
-	patterns := []string{":8080", "PORT=", "-p ", "--port="}
-
-	for _, pattern := range patterns {
-		if idx := strings.Index(r.CmdStr, pattern); idx != -1 {
-			// Extract the port number
-			portStr := r.CmdStr[idx+len(pattern):]
-			portStr = strings.Split(portStr, " ")[0]
-			if port, err := strconv.Atoi(strings.TrimPrefix(portStr, ":")); err == nil {
-				return port
-			}
-		}
-	}
-	return 8080 // default port for many web servers
+	// Port overrides port-release detection in Start, skipping
+	// command/env sniffing. Zero means "detect automatically".
+	Port int
+
+	// Stdout and Stderr, if set, receive a copy of the child's
+	// stdout/stderr in addition to the parent process's own streams.
+	// The control plane (internal/ctrl) uses these to tee output to
+	// remote subscribers.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Reap enables subreaper-aware waiting: instead of calling cmd.Wait
+	// directly, the wait goroutine watches reaper.Default.Exits for this
+	// child's PID. This avoids racing the SIGCHLD handler installed by
+	// reaper.Enable, which otherwise may reap the child via
+	// waitpid(-1, ...) before cmd.Wait gets a chance to.
+	Reap bool
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	exited chan struct{} // closed by the wait goroutine when cmd exits
 }
 
-// waitForPortRelease waits until the specified port is available
-func (r *Runner) waitForPortRelease(port int) {
-	start := time.Now()
-	for time.Since(start) < 5*time.Second {
-		conn, err := net.DialTimeout("tcp", fmt.Sprintf(":%d", port), 100*time.Millisecond)
-		if err != nil {
-			// Port is available
-			return
-		}
-		if conn != nil {
-			conn.Close()
+// New creates a Runner. If reap is true, Runner asks reaper.Default to
+// install itself as a Linux subreaper (see internal/reaper). If that fails,
+// as it always does outside Linux, Reap stays false and the error is
+// printed to stderr only when verbose is set.
+func New(cmdStr string, verbose, reap bool) *Runner {
+	if reap && !reaper.Default.Enabled() {
+		if err := reaper.Default.Enable(); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "[gowatch] %v\n", err)
 		}
-		time.Sleep(100 * time.Millisecond)
-	}
-	if r.Verbose {
-		fmt.Printf("[gowatch] warning: port %d might still be in use\n", port)
 	}
-}
-
-func New(cmdStr string, verbose bool) *Runner {
-	return &Runner{CmdStr: cmdStr, Verbose: verbose}
+	return &Runner{CmdStr: cmdStr, Verbose: verbose, Reap: reap && reaper.Default.Enabled()}
 }
 
 // Start launches the configured command (uses a shell) and returns any start error.
@@ -71,9 +64,18 @@ func (r *Runner) Start() error {
 		return fmt.Errorf("process already running")
 	}
 
-	// Wait for port to be released before starting
-	port := r.extractPort()
-	r.waitForPortRelease(port)
+	// Wait for the port to actually be released before starting: a failed
+	// TCP dial doesn't prove the port is free (nothing may have bound it
+	// yet), so we check the kernel's own listening-socket state instead.
+	port := r.Port
+	if port == 0 {
+		if p, ok := portcheck.ExtractPort(r.CmdStr, os.Environ()); ok {
+			port = p
+		} else {
+			port = 8080
+		}
+	}
+	portcheck.WaitForRelease(port, 5*time.Second, r.Verbose)
 
 	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
@@ -85,8 +87,14 @@ func (r *Runner) Start() error {
 	// Set process group for better process management
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdout = io.Writer(os.Stdout)
+	if r.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(os.Stdout, r.Stdout)
+	}
+	cmd.Stderr = io.Writer(os.Stderr)
+	if r.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(os.Stderr, r.Stderr)
+	}
 	cmd.Stdin = os.Stdin
 
 	if r.Verbose {
@@ -98,56 +106,94 @@ func (r *Runner) Start() error {
 	}
 
 	r.cmd = cmd
+	exited := make(chan struct{})
+	r.exited = exited
+
+	if r.Reap {
+		go r.waitReaped(cmd, exited)
+	} else {
+		go func(cmd *exec.Cmd) {
+			err := cmd.Wait()
+			if err != nil && r.Verbose {
+				fmt.Fprintf(os.Stderr, "[gowatch] child exited with error: %v\n", err)
+			} else if r.Verbose {
+				fmt.Printf("[gowatch] child exited\n")
+			}
+			r.mu.Lock()
+			if r.cmd == cmd {
+				r.cmd = nil
+			}
+			r.mu.Unlock()
+			close(exited)
+		}(cmd)
+	}
 
-	go func(cmd *exec.Cmd) {
-		err := cmd.Wait()
-		if err != nil && r.Verbose {
-			fmt.Fprintf(os.Stderr, "[gowatch] child exited with error: %v\n", err)
-		} else if r.Verbose {
-			fmt.Printf("[gowatch] child exited\n")
+	return nil
+}
+
+// waitReaped watches reaper.Default.Exits for cmd's PID instead of
+// calling cmd.Wait directly, so it doesn't race the SIGCHLD handler for
+// ownership of this child's exit status. Stop also relies on exited being
+// closed here rather than calling cmd.Wait itself, for the same reason.
+func (r *Runner) waitReaped(cmd *exec.Cmd, exited chan struct{}) {
+	pid := cmd.Process.Pid
+	for ev := range reaper.Default.Exits {
+		if ev.Pid != pid {
+			continue
+		}
+		if r.Verbose {
+			if ev.ExitCode != 0 {
+				fmt.Fprintf(os.Stderr, "[gowatch] child exited with code %d\n", ev.ExitCode)
+			} else {
+				fmt.Println("[gowatch] child exited")
+			}
 		}
 		r.mu.Lock()
 		if r.cmd == cmd {
 			r.cmd = nil
 		}
 		r.mu.Unlock()
-	}(cmd)
-
-	return nil
+		close(exited)
+		return
+	}
 }
 
 // Stop attempts a graceful shutdown then kills the process if it doesn't exit quickly.
 func (r *Runner) Stop() {
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	cmd := r.cmd
+	exited := r.exited
+	r.mu.Unlock()
 
-	if r.cmd == nil || r.cmd.Process == nil {
+	if cmd == nil || cmd.Process == nil {
 		return
 	}
 
 	if r.Verbose {
-		fmt.Printf("[gowatch] stopping pid %d\n", r.cmd.Process.Pid)
+		fmt.Printf("[gowatch] stopping pid %d\n", cmd.Process.Pid)
 	}
 
 	if runtime.GOOS == "windows" {
-		_ = r.cmd.Process.Kill()
+		// cmd.Process.Kill only terminates the immediate child, leaving
+		// anything it spawned (e.g. "go run" leaves its compiled binary
+		// running) orphaned; taskkill /T walks the process tree.
+		if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run(); err != nil {
+			_ = cmd.Process.Kill()
+		}
 	} else {
 		// Send SIGTERM to process group
-		pgid, err := syscall.Getpgid(r.cmd.Process.Pid)
+		pgid, err := syscall.Getpgid(cmd.Process.Pid)
 		if err == nil {
 			_ = syscall.Kill(-pgid, syscall.SIGTERM)
 		}
 
-		// Wait for graceful shutdown
-		done := make(chan struct{})
-		go func() {
-			r.cmd.Wait()
-			close(done)
-		}()
-
-		// Allow more time for graceful shutdown of servers
+		// Wait for graceful shutdown. exited is closed by Start's wait
+		// goroutine (either the cmd.Wait path or waitReaped), never by
+		// Stop itself calling cmd.Wait directly — in reap mode that
+		// would race the SIGCHLD handler's waitpid(-1, WNOHANG) for
+		// ownership of this same child's exit status.
 		select {
-		case <-done:
+		case <-exited:
 			if r.Verbose {
 				fmt.Println("[gowatch] process exited gracefully")
 			}
@@ -158,16 +204,32 @@ func (r *Runner) Stop() {
 			if pgid != 0 {
 				_ = syscall.Kill(-pgid, syscall.SIGKILL)
 			}
-			_ = r.cmd.Process.Kill()
+			_ = cmd.Process.Kill()
 		}
 	}
 
-	r.cmd = nil
+	r.mu.Lock()
+	if r.cmd == cmd {
+		r.cmd = nil
+	}
+	r.mu.Unlock()
 
 	// Add a small delay after stopping to ensure cleanup
 	time.Sleep(500 * time.Millisecond)
 }
 
+// Restart swaps in a new command string and starts it in place of whatever
+// is currently running, stopping the old child first if necessary. It is
+// used by the build-then-run workflow to switch to a freshly built binary
+// without the caller having to juggle Stop/Start itself.
+func (r *Runner) Restart(cmdStr string) error {
+	r.Stop()
+	r.mu.Lock()
+	r.CmdStr = cmdStr
+	r.mu.Unlock()
+	return r.Start()
+}
+
 // Signal forwards an OS signal to the child and then ensures it is killed shortly after.
 func (r *Runner) Signal(sig os.Signal) {
 	r.mu.Lock()
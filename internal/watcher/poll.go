@@ -0,0 +1,107 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PollingWatcher polls the filesystem on a fixed interval and emits a
+// restart signal when any watched file's mtime, or the set of watched
+// files, changes. It has no external dependencies, so it works on any
+// platform or filesystem, at the cost of walking the whole tree every
+// tick.
+type PollingWatcher struct {
+	Root      string
+	Interval  time.Duration
+	Exts      map[string]struct{}
+	IgnoreVcs bool
+	Verbose   bool
+}
+
+func newPollingWatcher(opts Options) *PollingWatcher {
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+	return &PollingWatcher{
+		Root:      root,
+		Interval:  opts.Interval,
+		Exts:      opts.Exts,
+		IgnoreVcs: opts.IgnoreVcs,
+		Verbose:   opts.Verbose,
+	}
+}
+
+// Start begins polling and returns a channel that receives when a restart should occur.
+// The channel is closed when ctx is cancelled.
+func (w *PollingWatcher) Start(ctx context.Context) (<-chan struct{}, error) {
+	restartCh := make(chan struct{}, 1)
+
+	mtimes, err := scanFiles(w.Root, w.Exts, w.IgnoreVcs)
+	if err != nil {
+		return nil, fmt.Errorf("initial scan error: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		defer close(restartCh)
+		for {
+			select {
+			case <-ticker.C:
+				new, err := scanFiles(w.Root, w.Exts, w.IgnoreVcs)
+				if err != nil {
+					if w.Verbose {
+						fmt.Fprintf(os.Stderr, "scan error: %v\n", err)
+					}
+					continue
+				}
+				if changed(mtimes, new) {
+					if w.Verbose {
+						fmt.Println("change detected, signaling restart")
+					}
+					mtimes = new
+					select {
+					case restartCh <- struct{}{}:
+					default:
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return restartCh, nil
+}
+
+// scanFiles walks directory and records mod times for files with matching extensions
+func scanFiles(root string, exts map[string]struct{}, ignoreVcs bool) (map[string]time.Time, error) {
+	out := make(map[string]time.Time)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// skip files we can't stat
+			return nil
+		}
+		if info.IsDir() {
+			base := filepath.Base(path)
+			if ignoreVcs && (base == ".git" || base == ".hg" || base == ".svn") {
+				return filepath.SkipDir
+			}
+			if strings.HasPrefix(base, ".") && base != "." {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if _, ok := exts[ext]; ok {
+			out[path] = info.ModTime()
+		}
+		return nil
+	})
+	return out, err
+}
@@ -1,28 +1,68 @@
+// Package watcher detects filesystem changes and signals when a restart
+// should occur. Detection is pluggable: PollingWatcher walks the tree on
+// an interval (portable, no external deps), while EventWatcher subscribes
+// to OS-level notifications via fsnotify for much lower latency and CPU
+// use on large trees.
 package watcher
 
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 )
 
-// Watcher polls the filesystem and emits restart notifications when files change.
-type Watcher struct {
+// Watcher detects file changes and emits a restart signal on the returned
+// channel. The channel is closed when ctx is cancelled.
+type Watcher interface {
+	Start(ctx context.Context) (<-chan struct{}, error)
+}
+
+// Backend selects which Watcher implementation New constructs.
+type Backend string
+
+const (
+	BackendPoll  Backend = "poll"
+	BackendEvent Backend = "event"
+	BackendAuto  Backend = "auto"
+)
+
+// Options bundles the parameters needed to construct any Watcher backend.
+// Interval is only used by PollingWatcher. Neither backend debounces
+// internally — both signal on every change they detect, leaving bursts to
+// be coalesced once at the app level (see cmd/goblip's debounce helper),
+// rather than debouncing twice at different layers with the same window.
+type Options struct {
+	Backend   Backend
+	Root      string // directory to watch; defaults to "."
 	Interval  time.Duration
 	Exts      map[string]struct{}
 	IgnoreVcs bool
 	Verbose   bool
 }
 
-func New(interval time.Duration, exts map[string]struct{}, ignoreVcs, verbose bool) *Watcher {
-	return &Watcher{
-		Interval:  interval,
-		Exts:      exts,
-		IgnoreVcs: ignoreVcs,
-		Verbose:   verbose,
+// New constructs a Watcher for the requested backend. BackendAuto tries
+// EventWatcher first and falls back to PollingWatcher if fsnotify fails
+// to initialize (e.g. on a filesystem or platform it doesn't support),
+// matching the watch-spring pattern of keeping polling around as a safety
+// net for portability.
+func New(opts Options) (Watcher, error) {
+	switch opts.Backend {
+	case BackendEvent:
+		return newEventWatcher(opts)
+	case BackendPoll, "":
+		return newPollingWatcher(opts), nil
+	case BackendAuto:
+		ew, err := newEventWatcher(opts)
+		if err == nil {
+			return ew, nil
+		}
+		if opts.Verbose {
+			fmt.Printf("[gowatch] event backend unavailable (%v), falling back to polling\n", err)
+		}
+		return newPollingWatcher(opts), nil
+	default:
+		return nil, fmt.Errorf("watcher: unknown backend %q", opts.Backend)
 	}
 }
 
@@ -42,76 +82,6 @@ func ParseExts(s string) map[string]struct{} {
 	return out
 }
 
-// Start begins watching and returns a channel that receives when a restart should occur.
-// The channel is closed when ctx is cancelled.
-func (w *Watcher) Start(ctx context.Context) (<-chan struct{}, error) {
-	restartCh := make(chan struct{}, 1)
-
-	mtimes, err := scanFiles(".", w.Exts, w.IgnoreVcs)
-	if err != nil {
-		return nil, fmt.Errorf("initial scan error: %w", err)
-	}
-
-	go func() {
-		ticker := time.NewTicker(w.Interval)
-		defer ticker.Stop()
-		defer close(restartCh)
-		for {
-			select {
-			case <-ticker.C:
-				new, err := scanFiles(".", w.Exts, w.IgnoreVcs)
-				if err != nil {
-					if w.Verbose {
-						fmt.Fprintf(os.Stderr, "scan error: %v\n", err)
-					}
-					continue
-				}
-				if changed(mtimes, new) {
-					if w.Verbose {
-						fmt.Println("change detected, signaling restart")
-					}
-					mtimes = new
-					select {
-					case restartCh <- struct{}{}:
-					default:
-					}
-				}
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-
-	return restartCh, nil
-}
-
-// scanFiles walks directory and records mod times for files with matching extensions
-func scanFiles(root string, exts map[string]struct{}, ignoreVcs bool) (map[string]time.Time, error) {
-	out := make(map[string]time.Time)
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// skip files we can't stat
-			return nil
-		}
-		if info.IsDir() {
-			base := filepath.Base(path)
-			if ignoreVcs && (base == ".git" || base == ".hg" || base == ".svn") {
-				return filepath.SkipDir
-			}
-			if strings.HasPrefix(base, ".") && base != "." {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-		ext := filepath.Ext(path)
-		if _, ok := exts[ext]; ok {
-			out[path] = info.ModTime()
-		}
-		return nil
-	})
-	return out, err
-}
-
 // changed compares two mtimes maps; returns true if any file was added, removed, or modtime changed.
 func changed(old, now map[string]time.Time) bool {
 	if len(old) != len(now) {
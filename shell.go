@@ -0,0 +1,203 @@
+package goblip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayushdoesdev/goblip/internal/watcher"
+)
+
+// Shell lets Go code — including go test harnesses — start and stop
+// watched subprocesses programmatically, without going through the
+// goblip CLI. It exposes the same restart primitives main() uses, so
+// integration tests can spin up a server, edit its source, and assert
+// that a watch would trigger a restart.
+type Shell struct {
+	// T, if set, routes subprocess failures to t.Errorf instead of
+	// exiting the program. Leave nil for non-test usage.
+	T *testing.T
+
+	Verbose bool
+}
+
+// NewShell creates a Shell. t may be nil outside of tests.
+func NewShell(t *testing.T) *Shell {
+	return &Shell{T: t}
+}
+
+// Cmd is a handle to a subprocess started by a Shell.
+type Cmd struct {
+	shell *Shell
+	cmd   *exec.Cmd
+	name  string
+	args  []string
+
+	// LogPath is the path of the temp file stdout/stderr are teed to.
+	// It is set once Start has been called.
+	LogPath string
+
+	mu   sync.Mutex
+	logf *os.File
+}
+
+// Cmd builds a Cmd for the given command and arguments. Call Start to
+// launch it.
+func (s *Shell) Cmd(name string, args ...string) *Cmd {
+	return &Cmd{shell: s, name: name, args: args}
+}
+
+// Start launches the process. Stdout/stderr are teed to the parent's own
+// streams and to a per-process temp file whose path is exposed as LogPath.
+func (c *Cmd) Start() error {
+	logf, err := os.CreateTemp("", "goblip-shell-*.log")
+	if err != nil {
+		return c.fail(fmt.Errorf("goblip: create log file: %w", err))
+	}
+	c.logf = logf
+	c.LogPath = logf.Name()
+
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdout = io.MultiWriter(os.Stdout, logf)
+	cmd.Stderr = io.MultiWriter(os.Stderr, logf)
+	cmd.Stdin = os.Stdin
+
+	if c.shell.Verbose {
+		fmt.Printf("[goblip] starting: %s %v\n", c.name, c.args)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return c.fail(fmt.Errorf("goblip: start %s: %w", c.name, err))
+	}
+	c.cmd = cmd
+	return nil
+}
+
+// Wait blocks until the process exits, closing the per-process log file
+// once it does.
+func (c *Cmd) Wait() error {
+	if c.cmd == nil {
+		return c.fail(fmt.Errorf("goblip: command not started"))
+	}
+	err := c.cmd.Wait()
+
+	c.mu.Lock()
+	if c.logf != nil {
+		c.logf.Close()
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		return c.fail(fmt.Errorf("goblip: %s exited: %w", c.name, err))
+	}
+	return nil
+}
+
+// Signal forwards an OS signal to the running process.
+func (c *Cmd) Signal(sig os.Signal) error {
+	if c.cmd == nil || c.cmd.Process == nil {
+		return c.fail(fmt.Errorf("goblip: command not started"))
+	}
+	if err := c.cmd.Process.Signal(sig); err != nil {
+		return c.fail(fmt.Errorf("goblip: signal %s: %w", c.name, err))
+	}
+	return nil
+}
+
+func (c *Cmd) fail(err error) error {
+	if c.shell.T != nil {
+		c.shell.T.Errorf("%v", err)
+		return err
+	}
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+	return err
+}
+
+// Event reports that a watched path changed and a restart is warranted.
+type Event struct {
+	// Path is the root that was being watched when the change fired.
+	Path string
+}
+
+// WatchOptions configures Shell.Watch.
+type WatchOptions struct {
+	// Ext is a comma-separated list of extensions to watch, e.g.
+	// ".go,.html". Defaults to watcher's standard Go project set.
+	Ext string
+	// Backend selects poll, event, or auto (the default).
+	Backend   watcher.Backend
+	Interval  time.Duration
+	IgnoreVcs bool
+}
+
+// Watch watches each of the given paths and emits an Event on the
+// returned channel whenever a matching file changes under it. This lets
+// integration tests assert that a watcher would restart a process after
+// a source edit — something that, before Shell existed, was trapped
+// inside the CLI's main().
+func (s *Shell) Watch(ctx context.Context, paths []string, opts WatchOptions) (<-chan Event, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	ext := opts.Ext
+	if ext == "" {
+		ext = ".go,.mod,.sum,.tpl,.html,.css,.js"
+	}
+	exts := watcher.ParseExts(ext)
+
+	backend := opts.Backend
+	if backend == "" {
+		backend = watcher.BackendAuto
+	}
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 500 * time.Millisecond
+	}
+
+	events := make(chan Event, len(paths))
+	var wg sync.WaitGroup
+
+	for _, p := range paths {
+		w, err := watcher.New(watcher.Options{
+			Backend:   backend,
+			Root:      p,
+			Interval:  interval,
+			Exts:      exts,
+			IgnoreVcs: opts.IgnoreVcs,
+			Verbose:   s.Verbose,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("goblip: watch %s: %w", p, err)
+		}
+		restartCh, err := w.Start(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("goblip: watch %s: %w", p, err)
+		}
+
+		wg.Add(1)
+		go func(path string, in <-chan struct{}) {
+			defer wg.Done()
+			for range in {
+				select {
+				case events <- Event{Path: path}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(p, restartCh)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events, nil
+}
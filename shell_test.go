@@ -0,0 +1,77 @@
+package goblip
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushdoesdev/goblip/internal/watcher"
+)
+
+// TestShellWatch_RestartsOnEdit exercises the motivating use case from the
+// Shell API: spin up a watch on a temp dir, edit a watched file, and assert
+// a restart Event arrives.
+func TestShellWatch_RestartsOnEdit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write seed file: %v", err)
+	}
+
+	s := NewShell(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.Watch(ctx, []string{dir}, WatchOptions{
+		Backend:  watcher.BackendPoll,
+		Interval: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	// The poller records its initial mtimes before Watch returns; pausing
+	// before the edit keeps its mtime apart from the seed write's.
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatalf("edit file: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Path != dir {
+			t.Errorf("Event.Path = %q, want %q", ev.Path, dir)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for restart event after file edit")
+	}
+}
+
+// TestShellCmd_StartWait exercises the Cmd handle returned by Shell.Cmd,
+// including the per-process log file Start sets up.
+func TestShellCmd_StartWait(t *testing.T) {
+	s := NewShell(t)
+	c := s.Cmd("sh", "-c", "echo hello")
+
+	if err := c.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if c.LogPath == "" {
+		t.Fatal("LogPath not set after Start")
+	}
+	defer os.Remove(c.LogPath)
+
+	if err := c.Wait(); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	out, err := os.ReadFile(c.LogPath)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if got := string(out); got != "hello\n" {
+		t.Errorf("log file = %q, want %q", got, "hello\n")
+	}
+}